@@ -0,0 +1,155 @@
+package fileio
+
+import (
+	"context"
+	"sync"
+)
+
+// BatchResult carries the outcome of a single entry of a batch transfer.
+type BatchResult struct {
+	// File is the path of the uploaded file, or the destination file of a
+	// downloaded one.
+	File string
+	// Key is the File.io key of the uploaded or downloaded file.
+	Key string
+	// Expiry is the expiry duration returned by the API, set on upload only.
+	Expiry string
+	// Err is the error encountered while processing this entry, if any.
+	Err error
+}
+
+// DownloadEntry identifies a single file to retrieve in a batch download.
+type DownloadEntry struct {
+	// Key is the File.io key of the file to download.
+	Key string
+	// File is the local destination path.
+	File string
+}
+
+// BatchOption configures a batch transfer.
+type BatchOption func(*batchConfig)
+
+type batchConfig struct {
+	failFast bool
+	expires  int
+	opts     []TransferOption
+}
+
+// WithFailFast aborts the remaining entries of a batch as soon as one of
+// them fails, instead of collecting a partial failure for every entry.
+func WithFailFast() BatchOption {
+	return func(c *batchConfig) { c.failFast = true }
+}
+
+// WithBatchExpires sets the expires in days applied to every file of a
+// BatchUpload. With no option, the default expires of 14 days is used.
+func WithBatchExpires(days int) BatchOption {
+	return func(c *batchConfig) { c.expires = days }
+}
+
+// WithBatchTransferOptions forwards opts to every individual upload or
+// download performed by the batch.
+func WithBatchTransferOptions(opts ...TransferOption) BatchOption {
+	return func(c *batchConfig) { c.opts = opts }
+}
+
+func newBatchConfig(opts []BatchOption) *batchConfig {
+	c := &batchConfig{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// BatchUpload uploads files concurrently, bounded by concurrency, and
+// returns one BatchResult per file, preserving input order. A failure
+// uploading one file does not abort the others, unless WithFailFast is set.
+func BatchUpload(ctx context.Context, files []string, concurrency int, opts ...BatchOption) ([]BatchResult, error) {
+	return DefaultClient.BatchUpload(ctx, files, concurrency, opts...)
+}
+
+// BatchDownload downloads files concurrently, bounded by concurrency, and
+// returns one BatchResult per entry, preserving input order. A failure
+// downloading one file does not abort the others, unless WithFailFast is set.
+func BatchDownload(ctx context.Context, entries []DownloadEntry, concurrency int, opts ...BatchOption) ([]BatchResult, error) {
+	return DefaultClient.BatchDownload(ctx, entries, concurrency, opts...)
+}
+
+// BatchUpload uploads files concurrently, bounded by concurrency, and
+// returns one BatchResult per file, preserving input order. A failure
+// uploading one file does not abort the others, unless WithFailFast is set.
+func (c *Client) BatchUpload(ctx context.Context, files []string, concurrency int, opts ...BatchOption) ([]BatchResult, error) {
+	cfg := newBatchConfig(opts)
+	results := make([]BatchResult, len(files))
+
+	err := runBatch(ctx, len(files), concurrency, cfg.failFast, func(ctx context.Context, i int) error {
+		rs := BatchResult{File: files[i]}
+		if cfg.expires > 0 {
+			rs.Key, rs.Expiry, rs.Err = c.UploadWithExpire(ctx, rs.File, cfg.expires, cfg.opts...)
+		} else {
+			rs.Key, rs.Err = c.Upload(ctx, rs.File, cfg.opts...)
+		}
+		results[i] = rs
+		return rs.Err
+	})
+	return results, err
+}
+
+// BatchDownload downloads files concurrently, bounded by concurrency, and
+// returns one BatchResult per entry, preserving input order. A failure
+// downloading one file does not abort the others, unless WithFailFast is set.
+func (c *Client) BatchDownload(ctx context.Context, entries []DownloadEntry, concurrency int, opts ...BatchOption) ([]BatchResult, error) {
+	cfg := newBatchConfig(opts)
+	results := make([]BatchResult, len(entries))
+
+	err := runBatch(ctx, len(entries), concurrency, cfg.failFast, func(ctx context.Context, i int) error {
+		rs := BatchResult{File: entries[i].File, Key: entries[i].Key}
+		rs.Err = c.Download(ctx, rs.Key, rs.File)
+		results[i] = rs
+		return rs.Err
+	})
+	return results, err
+}
+
+// runBatch calls fn(ctx, i) for i in [0,n) concurrently, bounded by a
+// semaphore of size concurrency. With failFast, ctx is canceled as soon as
+// one entry fails, no further entry is started, and the first error is
+// returned once the entries already running have finished; otherwise every
+// entry runs to completion and nil is returned, individual errors being
+// carried by fn itself.
+func runBatch(ctx context.Context, n, concurrency int, failFast bool, fn func(context.Context, int) error) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+		sem      = make(chan struct{}, concurrency)
+	)
+	for i := 0; i < n; i++ {
+		if ctx.Err() != nil {
+			break
+		}
+		i := i
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := fn(ctx, i); err != nil && failFast {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	return firstErr
+}
@@ -0,0 +1,84 @@
+package fileio
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+)
+
+// TestBatchUpload tests the method BatchUpload.
+func TestBatchUpload(t *testing.T) {
+	DefaultClient.HTTPClient = &fakeHTTPClient{}
+	defer func() { DefaultClient.HTTPClient = http.DefaultClient }()
+
+	base := DefaultClient.BaseURL
+	defer func() { DefaultClient.BaseURL = base }()
+	DefaultClient.BaseURL = URL
+
+	// Ensures the fixture exists, regardless of test run order.
+	if err := ioutil.WriteFile("test.txt", []byte("This is a test"), 0644); err != nil {
+		t.Fatalf("failed to create fixture: %v", err)
+	}
+	defer func() { _ = os.Remove("test.txt") }()
+
+	files := []string{"test.txt", "test.txt", "missing.txt"}
+	results, err := BatchUpload(context.Background(), files, 2)
+	if err != nil {
+		t.Fatalf("expected no error, received %v", err)
+	}
+	if len(results) != len(files) {
+		t.Fatalf("expected %d results, received %d", len(files), len(results))
+	}
+	if results[0].Key != "2ojE41" || results[0].Err != nil {
+		t.Errorf("expected a successful upload of %s, received %+v", files[0], results[0])
+	}
+	if results[2].Err == nil {
+		t.Errorf("expected an error uploading %s, received none", files[2])
+	}
+}
+
+// TestBatchUploadFailFast tests that WithFailFast stops the batch on the first error.
+func TestBatchUploadFailFast(t *testing.T) {
+	DefaultClient.HTTPClient = &fakeHTTPClient{}
+	defer func() { DefaultClient.HTTPClient = http.DefaultClient }()
+
+	base := DefaultClient.BaseURL
+	defer func() { DefaultClient.BaseURL = base }()
+	DefaultClient.BaseURL = URL
+
+	files := []string{"missing.txt"}
+	if _, err := BatchUpload(context.Background(), files, 1, WithFailFast()); err == nil {
+		t.Fatal("expected an error, received none")
+	}
+}
+
+// TestBatchDownload tests the method BatchDownload.
+func TestBatchDownload(t *testing.T) {
+	DefaultClient.HTTPClient = &fakeHTTPClient{}
+	defer func() { DefaultClient.HTTPClient = http.DefaultClient }()
+
+	base := DefaultClient.BaseURL
+	defer func() { DefaultClient.BaseURL = base }()
+	DefaultClient.BaseURL = URL
+
+	entries := []DownloadEntry{
+		{Key: "exists", File: "batch1.txt"},
+		{Key: "not_exists", File: "batch2.txt"},
+	}
+	defer func() {
+		_ = os.Remove("batch1.txt")
+		_ = os.Remove("batch2.txt")
+	}()
+	results, err := BatchDownload(context.Background(), entries, 2)
+	if err != nil {
+		t.Fatalf("expected no error, received %v", err)
+	}
+	if results[0].Err != nil {
+		t.Errorf("expected no error downloading %s, received %v", entries[0].Key, results[0].Err)
+	}
+	if results[1].Err == nil {
+		t.Errorf("expected an error downloading %s, received none", entries[1].Key)
+	}
+}
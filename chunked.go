@@ -0,0 +1,228 @@
+package fileio
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+)
+
+// ErrChunkedUploadUnsupported is returned by UploadChunked when the client
+// has no Client.ChunkedEndpoint configured. File.io itself is a single-shot
+// upload API; resumable uploads require a compatible self-hosted backend,
+// reachable at ChunkedEndpoint.
+var ErrChunkedUploadUnsupported = errors.New("fileio: chunked upload requires Client.ChunkedEndpoint")
+
+// WithChunkedEndpoint sets the endpoint of a backend compatible with
+// UploadChunked's transfer protocol.
+func WithChunkedEndpoint(url string) Option {
+	return func(c *Client) { c.ChunkedEndpoint = url }
+}
+
+// resumeState is the sidecar manifest persisted next to the uploaded file,
+// recording the chunks already sent so an interrupted transfer can resume.
+type resumeState struct {
+	OID       string `json:"oid"`
+	ChunkSize int64  `json:"chunkSize"`
+	Uploaded  []int  `json:"uploaded"`
+}
+
+func resumeFilePath(file string) string {
+	return file + ".fileio-resume.json"
+}
+
+// loadResumeState reads the sidecar manifest for file, starting fresh if it
+// is missing or was left by a different file content or chunk size.
+func loadResumeState(file, oid string, chunkSize int64) (*resumeState, error) {
+	data, err := ioutil.ReadFile(resumeFilePath(file))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &resumeState{OID: oid, ChunkSize: chunkSize}, nil
+		}
+		return nil, err
+	}
+
+	st := &resumeState{}
+	if err := json.Unmarshal(data, st); err != nil {
+		return nil, err
+	}
+	if st.OID != oid || st.ChunkSize != chunkSize {
+		return &resumeState{OID: oid, ChunkSize: chunkSize}, nil
+	}
+	return st, nil
+}
+
+func (st *resumeState) save(file string) error {
+	data, err := json.Marshal(st)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(resumeFilePath(file), data, 0644)
+}
+
+func (st *resumeState) hasUploaded(index int) bool {
+	for _, i := range st.Uploaded {
+		if i == index {
+			return true
+		}
+	}
+	return false
+}
+
+func (st *resumeState) markUploaded(index int) {
+	if !st.hasUploaded(index) {
+		st.Uploaded = append(st.Uploaded, index)
+	}
+}
+
+// UploadChunked uploads file to the client's ChunkedEndpoint in chunks of
+// chunkSize bytes, resuming from a sidecar manifest if a previous attempt
+// was interrupted. A SHA-256 OID is computed for the whole file up-front and
+// used to identify it across the manifest, the chunk uploads and the final
+// verify call. It returns the API response, or an error if it can not.
+func UploadChunked(ctx context.Context, file string, chunkSize int64, opts ...TransferOption) (*Response, error) {
+	return DefaultClient.UploadChunked(ctx, file, chunkSize, opts...)
+}
+
+// UploadChunked uploads file to the client's ChunkedEndpoint in chunks of
+// chunkSize bytes, resuming from a sidecar manifest if a previous attempt
+// was interrupted. A SHA-256 OID is computed for the whole file up-front and
+// used to identify it across the manifest, the chunk uploads and the final
+// verify call. It returns the API response, or an error if it can not.
+func (c *Client) UploadChunked(ctx context.Context, file string, chunkSize int64, opts ...TransferOption) (*Response, error) {
+	if c.ChunkedEndpoint == "" {
+		return nil, ErrChunkedUploadUnsupported
+	}
+	if chunkSize <= 0 {
+		return nil, fmt.Errorf("fileio: chunkSize must be positive, got %d", chunkSize)
+	}
+	cfg := newTransferConfig(opts)
+
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := fi.Size()
+
+	oid, err := sha256OID(f)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	total := int(size / chunkSize)
+	if size%chunkSize != 0 {
+		total++
+	}
+	if total == 0 {
+		total = 1
+	}
+
+	st, err := loadResumeState(file, oid, chunkSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var done int64
+	for i := 0; i < total; i++ {
+		n := chunkLen(i, total, chunkSize, size)
+		if st.hasUploaded(i) {
+			done += n
+			if _, err := f.Seek(n, io.SeekCurrent); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		buf := make([]byte, n)
+		if _, err := io.ReadFull(f, buf); err != nil {
+			return nil, err
+		}
+		if err := c.uploadChunk(ctx, oid, i, buf); err != nil {
+			return nil, err
+		}
+
+		st.markUploaded(i)
+		if err := st.save(file); err != nil {
+			return nil, err
+		}
+
+		done += n
+		if cfg.progress != nil {
+			cfg.progress(done, size)
+		}
+	}
+
+	rs, err := c.finalizeChunked(ctx, oid)
+	if err != nil {
+		return nil, err
+	}
+	_ = os.Remove(resumeFilePath(file))
+	return rs, nil
+}
+
+// uploadChunk sends the index-th chunk of oid to the ChunkedEndpoint.
+func (c *Client) uploadChunk(ctx context.Context, oid string, index int, buf []byte) error {
+	url := fmt.Sprintf("%s/%s/chunks/%d", c.ChunkedEndpoint, oid, index)
+	resp, err := c.do(ctx, http.MethodPut, url, "application/octet-stream", bytes.NewReader(buf))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
+		return statusError(oid, resp)
+	}
+	return nil
+}
+
+// finalizeChunked asks the ChunkedEndpoint to assemble and verify the
+// uploaded chunks of oid, returning the resulting API response.
+func (c *Client) finalizeChunked(ctx context.Context, oid string) (*Response, error) {
+	url := fmt.Sprintf("%s/%s/finalize", c.ChunkedEndpoint, oid)
+	resp, err := c.do(ctx, http.MethodPost, url, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	rs, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return parseJSON(rs, resp.StatusCode)
+}
+
+// chunkLen returns the size of the index-th chunk out of total, given the
+// nominal chunkSize and the overall file size.
+func chunkLen(index, total int, chunkSize, size int64) int64 {
+	if index == total-1 {
+		return size - int64(index)*chunkSize
+	}
+	return chunkSize
+}
+
+// sha256OID computes the SHA-256 hex digest of r, used as the OID
+// identifying the file across a chunked transfer.
+func sha256OID(r io.Reader) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
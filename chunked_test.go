@@ -0,0 +1,95 @@
+package fileio
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+// fakeChunkedHTTPClient mocks a chunked-upload backend: it accepts any chunk
+// PUT and returns a success response on finalize.
+type fakeChunkedHTTPClient struct {
+	chunks int
+}
+
+func (c *fakeChunkedHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	w := httptest.NewRecorder()
+	switch req.Method {
+	case http.MethodPut:
+		c.chunks++
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodPost:
+		_, _ = w.Write([]byte(`{"success":true,"key":"2ojE41"}`))
+	}
+	return w.Result(), nil
+}
+
+// TestUploadChunked tests the method UploadChunked.
+func TestUploadChunked(t *testing.T) {
+	file := "chunked.txt"
+	if err := ioutil.WriteFile(file, []byte("0123456789"), 0644); err != nil {
+		t.Fatalf("failed to create fixture: %v", err)
+	}
+	defer func() { _ = os.Remove(file) }()
+	defer func() { _ = os.Remove(resumeFilePath(file)) }()
+
+	fc := &fakeChunkedHTTPClient{}
+	c := NewClient(WithHTTPClient(fc), WithChunkedEndpoint("https://example.com/chunked"))
+
+	rs, err := c.UploadChunked(context.Background(), file, 4)
+	if err != nil {
+		t.Fatalf("expected no error, received %v", err)
+	}
+	if rs.Key != "2ojE41" {
+		t.Errorf("expected key 2ojE41, received %s", rs.Key)
+	}
+	if fc.chunks != 3 {
+		t.Errorf("expected 3 chunks uploaded, received %d", fc.chunks)
+	}
+	if _, err := os.Stat(resumeFilePath(file)); !os.IsNotExist(err) {
+		t.Errorf("expected the resume manifest to be removed on success")
+	}
+}
+
+// TestUploadChunkedUnsupported tests that UploadChunked rejects a client
+// with no ChunkedEndpoint configured.
+func TestUploadChunkedUnsupported(t *testing.T) {
+	c := NewClient()
+	if _, err := c.UploadChunked(context.Background(), "chunked.txt", 4); err != ErrChunkedUploadUnsupported {
+		t.Errorf("expected ErrChunkedUploadUnsupported, received %v", err)
+	}
+}
+
+// TestUploadChunkedResume tests that previously uploaded chunks, recorded in
+// the resume manifest, are skipped on a second attempt.
+func TestUploadChunkedResume(t *testing.T) {
+	file := "chunked_resume.txt"
+	if err := ioutil.WriteFile(file, []byte("0123456789"), 0644); err != nil {
+		t.Fatalf("failed to create fixture: %v", err)
+	}
+	defer func() { _ = os.Remove(file) }()
+	defer func() { _ = os.Remove(resumeFilePath(file)) }()
+
+	oid, err := sha256OID(strings.NewReader("0123456789"))
+	if err != nil {
+		t.Fatalf("failed to compute oid: %v", err)
+	}
+	st := &resumeState{OID: oid, ChunkSize: 4, Uploaded: []int{0}}
+	if err := st.save(file); err != nil {
+		t.Fatalf("failed to save fixture manifest: %v", err)
+	}
+
+	fc := &fakeChunkedHTTPClient{}
+	c := NewClient(WithHTTPClient(fc), WithChunkedEndpoint("https://example.com/chunked"))
+
+	if _, err := c.UploadChunked(context.Background(), file, 4); err != nil {
+		t.Fatalf("expected no error, received %v", err)
+	}
+	if fc.chunks != 2 {
+		t.Errorf("expected 2 chunks uploaded after resume, received %d", fc.chunks)
+	}
+}
@@ -0,0 +1,110 @@
+package fileio
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// APIError reports a File.io API failure, carrying the error code and
+// message returned by the API, the HTTP status of the response and, when
+// advised by the server, the duration to wait before retrying.
+type APIError struct {
+	Code       int
+	Message    string
+	HTTPStatus int
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("%s (retry after %s)", e.Message, e.RetryAfter)
+	}
+	return e.Message
+}
+
+// NotFoundError reports that the requested key does not exist on File.io.
+type NotFoundError struct {
+	Key string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("key %q not found", e.Key)
+}
+
+// TransportError reports a failure to reach the File.io API, wrapping the
+// underlying network error.
+type TransportError struct {
+	Err error
+}
+
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("transport error: %v", e.Err)
+}
+
+// Unwrap allows errors.Is and errors.As to reach the underlying network error.
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// ExpiryError reports an expires value rejected by the API.
+type ExpiryError struct {
+	Message string
+}
+
+func (e *ExpiryError) Error() string {
+	return e.Message
+}
+
+// RetryPolicy configures the retry behavior of idempotent GET requests.
+// With a zero value, no retry is attempted.
+type RetryPolicy struct {
+	// MaxRetries is the number of additional attempts after the first one.
+	MaxRetries int
+	// BaseDelay is the delay before the first retry. Every following retry
+	// doubles it, unless the server advises a Retry-After duration.
+	BaseDelay time.Duration
+}
+
+// WithRetryPolicy sets the retry policy applied to idempotent GET requests.
+func WithRetryPolicy(rp RetryPolicy) Option {
+	return func(c *Client) { c.RetryPolicy = rp }
+}
+
+// statusError builds the typed error matching a non-2xx response to key,
+// extracting the Retry-After duration when present.
+func statusError(key string, resp *http.Response) error {
+	if resp.StatusCode == http.StatusNotFound {
+		return &NotFoundError{Key: key}
+	}
+	return &APIError{
+		Code:       resp.StatusCode,
+		Message:    resp.Status,
+		HTTPStatus: resp.StatusCode,
+		RetryAfter: retryAfter(resp),
+	}
+}
+
+// retryAfter parses the Retry-After header of resp, supporting both the
+// delay-in-seconds and the HTTP-date forms.
+func retryAfter(resp *http.Response) time.Duration {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// isRetryable reports whether a response warrants a retry under RetryPolicy.
+func isRetryable(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable
+}
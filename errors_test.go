@@ -0,0 +1,32 @@
+package fileio
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryAfter(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	if d := retryAfter(resp); d != 0 {
+		t.Errorf("expected no delay without header, received %s", d)
+	}
+
+	resp.Header.Set("Retry-After", "5")
+	if d := retryAfter(resp); d != 5*time.Second {
+		t.Errorf("expected a 5s delay, received %s", d)
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	for status, want := range map[int]bool{
+		http.StatusTooManyRequests:     true,
+		http.StatusServiceUnavailable:  true,
+		http.StatusOK:                  false,
+		http.StatusInternalServerError: false,
+	} {
+		if got := isRetryable(&http.Response{StatusCode: status}); got != want {
+			t.Errorf("status %d: expected isRetryable=%v, received %v", status, want, got)
+		}
+	}
+}
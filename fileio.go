@@ -1,27 +1,27 @@
 package fileio
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
 	"io"
-	"io/ioutil"
-	"mime/multipart"
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // DefaultExpires is the default number of days until the file will be deleted by File.io.
 const DefaultExpires = 14
 
-// Enable testing by mocking the http client.
-type httpClient interface {
-	Get(url string) (*http.Response, error)
-	Post(url string, bodyType string, body io.Reader) (*http.Response, error)
-}
+// DefaultBaseURL is the default url of the File.io API.
+const DefaultBaseURL = "https://file.io"
 
-var api httpClient = http.DefaultClient
+// doer is implemented by http.Client. It allows a Client to be tested
+// without touching any package-level variable.
+type doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
 
 // Response contains a FileIO response.
 type Response struct {
@@ -32,21 +32,108 @@ type Response struct {
 	Key     string `json:"key,omitempty"`
 }
 
+// Option configures a Client on creation.
+type Option func(*Client)
+
+// WithBaseURL overrides the default File.io API endpoint, allowing
+// requests against a self-hosted mirror.
+func WithBaseURL(url string) Option {
+	return func(c *Client) { c.BaseURL = url }
+}
+
+// WithHTTPClient overrides the http client used to perform requests.
+func WithHTTPClient(h doer) Option {
+	return func(c *Client) { c.HTTPClient = h }
+}
+
+// WithAuthToken sets the bearer token sent with each request.
+func WithAuthToken(token string) Option {
+	return func(c *Client) { c.AuthToken = token }
+}
+
+// Client is a File.io API client. Unlike the package-level functions, a
+// Client is concurrency-safe: it can target any number of hosts at once
+// and every request accepts its own context.Context for cancellation or
+// timeouts. Use NewClient to create one.
+type Client struct {
+	// BaseURL is the url of the File.io API targeted by this client.
+	BaseURL string
+	// HTTPClient performs the HTTP requests. Defaults to http.DefaultClient.
+	HTTPClient doer
+	// AuthToken, when set, is sent as a Bearer token on each request.
+	AuthToken string
+	// RetryPolicy configures the retry behavior of idempotent GET requests.
+	// With its zero value, no retry is attempted.
+	RetryPolicy RetryPolicy
+	// ChunkedEndpoint, when set, enables UploadChunked against a backend
+	// compatible with its chunked transfer protocol. File.io itself has no
+	// such endpoint.
+	ChunkedEndpoint string
+}
+
+// NewClient returns a ready to use Client, applying the given options.
+// With no option, it targets the public File.io API with http.DefaultClient.
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// DefaultClient is the Client used by the package-level functions.
+var DefaultClient = NewClient()
+
 // URL is by default the url of the File.io API.
-var URL = "https://file.io"
+//
+// Deprecated: use WithBaseURL with NewClient, or set DefaultClient.BaseURL.
+var URL = DefaultBaseURL
 
 // Download downloads the file behind the key to the given file.
 // An error occurs if the key does not exist or if the file fails to be create.
-func Download(key, file string) (err error) {
+func Download(key, file string) error {
+	return defaultClient().Download(context.Background(), key, file)
+}
+
+// Upload uploads a file to file.io and returns its key or an error if it can not.
+// A default expires of 14 days is internally used.
+func Upload(file string, opts ...TransferOption) (string, error) {
+	return defaultClient().Upload(context.Background(), file, opts...)
+}
+
+// UploadWithExpire uploads a file to file.io and sets the expires in days.
+// It returns its key, the expiry duration and an error if it can not to get it.
+func UploadWithExpire(file string, days int, opts ...TransferOption) (string, string, error) {
+	return defaultClient().UploadWithExpire(context.Background(), file, days, opts...)
+}
+
+// defaultClient returns DefaultClient, or a copy of it targeting URL when URL
+// has been changed from DefaultBaseURL, honoring the deprecated variable
+// without mutating the shared DefaultClient.
+func defaultClient() *Client {
+	if URL == DefaultBaseURL {
+		return DefaultClient
+	}
+	c := *DefaultClient
+	c.BaseURL = URL
+	return &c
+}
+
+// Download downloads the file behind the key to the given file.
+// An error occurs if the key does not exist or if the file fails to be create.
+func (c *Client) Download(ctx context.Context, key, file string) (err error) {
 	// Downloads the file with this key on File.io.
 	var resp *http.Response
-	if resp, err = api.Get(URL + "/" + key); err != nil {
+	if resp, err = c.getWithRetry(ctx, c.url(key)); err != nil {
 		return
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		err = errors.New(resp.Status)
+		err = statusError(key, resp)
 		return
 	}
 
@@ -65,8 +152,8 @@ func Download(key, file string) (err error) {
 
 // Upload uploads a file to file.io and returns its key or an error if it can not.
 // A default expires of 14 days is internally used.
-func Upload(file string) (string, error) {
-	rs, err := postBody(file, URL)
+func (c *Client) Upload(ctx context.Context, file string, opts ...TransferOption) (string, error) {
+	rs, err := c.postBody(ctx, file, c.url(""), opts...)
 	if err != nil {
 		return "", err
 	}
@@ -75,14 +162,81 @@ func Upload(file string) (string, error) {
 
 // UploadWithExpire uploads a file to file.io and sets the expires in days.
 // It returns its key, the expiry duration and an error if it can not to get it.
-func UploadWithExpire(file string, days int) (string, string, error) {
-	rs, err := postBody(file, URL+"/?expires="+expires(days))
+func (c *Client) UploadWithExpire(ctx context.Context, file string, days int, opts ...TransferOption) (string, string, error) {
+	rs, err := c.postBody(ctx, file, c.url("")+"/?expires="+expires(days), opts...)
 	if err != nil {
 		return "", "", err
 	}
 	return rs.Key, rs.Expiry, nil
 }
 
+// url builds the endpoint for the given path under the client's BaseURL.
+func (c *Client) url(path string) string {
+	if path == "" {
+		return c.BaseURL
+	}
+	return c.BaseURL + "/" + path
+}
+
+func (c *Client) httpClient() doer {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// do builds and sends an HTTP request, honoring ctx and the client's auth token.
+func (c *Client) do(ctx context.Context, method, url, bodyType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	if bodyType != "" {
+		req.Header.Set("Content-Type", bodyType)
+	}
+	if c.AuthToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.AuthToken)
+	}
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, &TransportError{Err: err}
+	}
+	return resp, nil
+}
+
+// getWithRetry performs a GET request, retrying on 429 and 503 responses
+// according to RetryPolicy, honoring any Retry-After advised by the server.
+func (c *Client) getWithRetry(ctx context.Context, url string) (*http.Response, error) {
+	attempts := c.RetryPolicy.MaxRetries + 1
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var resp *http.Response
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if resp, err = c.do(ctx, http.MethodGet, url, "", nil); err != nil {
+			return nil, err
+		}
+		if !isRetryable(resp) || attempt == attempts-1 {
+			return resp, nil
+		}
+
+		wait := retryAfter(resp)
+		if wait == 0 {
+			wait = c.RetryPolicy.BaseDelay * time.Duration(1<<uint(attempt))
+		}
+		_ = resp.Body.Close()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+	return resp, nil
+}
+
 // Converts number of day as expected by the API.
 func expires(days int) string {
 	if days < 1 {
@@ -103,60 +257,31 @@ func expires(days int) string {
 // {"success":true,"key":"2ojE41"}
 // {"success":true,"key":"aQbnDJ","expiry":"7 days"}
 // {"success":false,"error":404,"message":"Not Found"}
-func parseJSON(data []byte) (*Response, error) {
+func parseJSON(data []byte, httpStatus int) (*Response, error) {
 	res := &Response{}
 	if err := json.Unmarshal(data, res); err != nil {
 		return res, err
 	}
 	// The action fails for File.io, deals with it.
 	if !res.Success {
-		return res, errors.New(res.Err)
+		if strings.Contains(strings.ToLower(res.Err), "expir") {
+			return res, &ExpiryError{Message: res.Err}
+		}
+		if res.Code == http.StatusNotFound {
+			return res, &NotFoundError{}
+		}
+		return res, &APIError{Code: res.Code, Message: res.Err, HTTPStatus: httpStatus}
 	}
 	return res, nil
 }
 
-func postBody(file, url string) (*Response, error) {
-	// Create the form to post.
-	body, bodyType, err := createBody(file)
-	if err != nil {
-		return nil, err
-	}
-	// Uploads the file.
-	resp, err := api.Post(url, bodyType, body)
-	if err != nil {
-		return nil, err
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	// Gets the response and parse it as JSON.
-	rs, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-	return parseJSON(rs)
-}
-
-func createBody(file string) (*bytes.Buffer, string, error) {
-	// Create a buffer for the form.
-	body := &bytes.Buffer{}
-	w := multipart.NewWriter(body)
-	defer func() { _ = w.Close() }()
-
-	fw, err := w.CreateFormFile("file", file)
-	if err != nil {
-		return nil, "", err
-	}
-
-	// Opens file handle.
+func (c *Client) postBody(ctx context.Context, file, url string, opts ...TransferOption) (*Response, error) {
+	// Opens the file to stream it as the multipart body.
 	f, err := os.Open(file)
 	if err != nil {
-		return nil, "", err
+		return nil, err
 	}
 	defer func() { _ = f.Close() }()
 
-	// Adds the file to the form.
-	if _, err = io.Copy(fw, f); err != nil {
-		return nil, "", err
-	}
-	return body, w.FormDataContentType(), nil
+	return c.uploadStream(ctx, file, f, url, opts...)
 }
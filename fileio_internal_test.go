@@ -3,6 +3,7 @@ package fileio
 import (
 	"errors"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -14,8 +15,8 @@ var downloadTest = []struct {
 	err            error
 }{
 	{url: URL, key: "exists", file: "test.txt"},
-	{url: URL, key: "not_exists", file: "test2.txt", err: errors.New("Not Found")},
-	{key: "fails", file: "test.txt", err: errors.New("No transport")},
+	{url: URL, key: "not_exists", file: "test2.txt", err: &NotFoundError{Key: "not_exists"}},
+	{key: "fails", file: "test.txt", err: &TransportError{Err: errors.New("No transport")}},
 }
 
 var uploadTest = []struct {
@@ -31,20 +32,23 @@ var uploadTest = []struct {
 	{expires: 730, url: URL, file: "test.txt", key: "2ojE41", expiry: "2 years"},
 	{expires: 999, url: URL, file: "test.txt", err: errors.New("unexpected end of JSON input")},
 	{url: URL, file: "test2.txt", err: errors.New("open test2.txt: no such file or directory")},
-	{file: "test.txt", err: errors.New("No transport")},
+	{file: "test.txt", err: &TransportError{Err: errors.New("No transport")}},
 }
 
-// Builds a fake http client by mocking main methods.
+// Builds a fake http client by mocking Do, the single method of the doer interface.
 type fakeHTTPClient struct{}
 
-// Get mocks the method of same name of the http package.
-func (c *fakeHTTPClient) Get(url string) (*http.Response, error) {
-	return fakeHTTPHandler(url, "GET")
-}
-
-// Post mocks the method of same name of the http package.
-func (c *fakeHTTPClient) Post(url string, bodyType string, body io.Reader) (*http.Response, error) {
-	return fakeHTTPHandler(url, "POST")
+// Do mocks the method of same name of the http package.
+func (c *fakeHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		// A real transport always reads the whole request body before a
+		// response is available; mimic that so streamed bodies complete.
+		defer func() { _ = req.Body.Close() }()
+		if _, err := io.Copy(ioutil.Discard, req.Body); err != nil {
+			return nil, err
+		}
+	}
+	return fakeHTTPHandler(req.URL.String(), req.Method)
 }
 
 func fakeHTTPHandler(url, method string) (*http.Response, error) {
@@ -62,24 +66,24 @@ func fakeHTTPHandler(url, method string) (*http.Response, error) {
 		}
 		switch p {
 		case "/", "/?expires=14":
-			_, _ = io.WriteString(w, `{"success":true,"key":"2ojE41"}`)
+			_, _ = w.Write([]byte(`{"success":true,"key":"2ojE41"}`))
 		case "/exists":
-			_, _ = io.WriteString(w, "This is a test")
+			_, _ = w.Write([]byte("This is a test"))
 		case "/?expires=1w":
-			_, _ = io.WriteString(w, `{"success":true,"key":"2ojE41","expiry":"7 days"}`)
+			_, _ = w.Write([]byte(`{"success":true,"key":"2ojE41","expiry":"7 days"}`))
 		case "/?expires=1m":
-			_, _ = io.WriteString(w, `{"success":true,"key":"2ojE41","expiry":"1 month"}`)
+			_, _ = w.Write([]byte(`{"success":true,"key":"2ojE41","expiry":"1 month"}`))
 		case "/?expires=2y":
-			_, _ = io.WriteString(w, `{"success":true,"key":"2ojE41","expiry":"2 years"}`)
+			_, _ = w.Write([]byte(`{"success":true,"key":"2ojE41","expiry":"2 years"}`))
 		case "/?expires=12":
-			_, _ = io.WriteString(w, `{"success":true,"key":"2ojE41","expiry":"12 days"}`)
+			_, _ = w.Write([]byte(`{"success":true,"key":"2ojE41","expiry":"12 days"}`))
 		case "/?expires=666":
-			_, _ = io.WriteString(w, `{"success":false,"error":500,"message":"Internal error"}`)
+			_, _ = w.Write([]byte(`{"success":false,"error":500,"message":"Internal error"}`))
 		case "/?expires=999":
-			_, _ = io.WriteString(w, "")
+			_, _ = w.Write([]byte(""))
 		default:
 			w.WriteHeader(http.StatusNotFound)
-			_, _ = io.WriteString(w, `{"success":false,"error":404,"message":"Not Found"}`)
+			_, _ = w.Write([]byte(`{"success":false,"error":404,"message":"Not Found"}`))
 		}
 	}
 
@@ -91,10 +95,10 @@ func fakeHTTPHandler(url, method string) (*http.Response, error) {
 
 // TestDownload tests the method Download.
 func TestDownload(t *testing.T) {
-	api = &fakeHTTPClient{}
+	DefaultClient.HTTPClient = &fakeHTTPClient{}
 
 	// Restore http client at the end of the test.
-	defer func() { api = http.DefaultClient }()
+	defer func() { DefaultClient.HTTPClient = http.DefaultClient }()
 
 	// Restore the default url of the API.
 	url := URL
@@ -116,10 +120,10 @@ func TestDownload(t *testing.T) {
 
 // TestUpload tests the method Upload.
 func TestUpload(t *testing.T) {
-	api = &fakeHTTPClient{}
+	DefaultClient.HTTPClient = &fakeHTTPClient{}
 
 	// Restore http client at the end of the test.
-	defer func() { api = http.DefaultClient }()
+	defer func() { DefaultClient.HTTPClient = http.DefaultClient }()
 
 	// Restore the default url of the API.
 	url := URL
@@ -146,10 +150,10 @@ func TestUpload(t *testing.T) {
 
 // TestUploadWithExpire tests the method UploadWithExpire.
 func TestUploadWithExpire(t *testing.T) {
-	api = &fakeHTTPClient{}
+	DefaultClient.HTTPClient = &fakeHTTPClient{}
 
 	// Restore http client at the end of the test.
-	defer func() { api = http.DefaultClient }()
+	defer func() { DefaultClient.HTTPClient = http.DefaultClient }()
 
 	// Restore the default url of the API.s
 	url := URL
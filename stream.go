@@ -0,0 +1,196 @@
+package fileio
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"strings"
+)
+
+// ProgressFunc is called as data is transferred, reporting the number of
+// bytes already processed and, when known, the total size of the transfer.
+// total is -1 when the size cannot be determined in advance.
+type ProgressFunc func(done, total int64)
+
+// transferConfig holds the per-call settings shared by upload and download
+// operations. It is populated by TransferOption.
+type transferConfig struct {
+	progress ProgressFunc
+
+	// Upload policy, enforced by validateUpload.
+	allowedMIME  []string
+	deniedMIME   []string
+	maxBytes     int64
+	renameRandom bool
+}
+
+// TransferOption configures an individual upload or download.
+type TransferOption func(*transferConfig)
+
+// WithProgress reports transfer progress through fn as data is copied.
+func WithProgress(fn ProgressFunc) TransferOption {
+	return func(c *transferConfig) { c.progress = fn }
+}
+
+func newTransferConfig(opts []TransferOption) *transferConfig {
+	c := &transferConfig{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// UploadReader uploads the content read from r, named as name, and returns
+// the API response or an error if it can not. As with Upload, the content is
+// streamed directly to the request body instead of being buffered in memory,
+// making it suitable for large files.
+func UploadReader(ctx context.Context, name string, r io.Reader, opts ...TransferOption) (*Response, error) {
+	return DefaultClient.UploadReader(ctx, name, r, opts...)
+}
+
+// DownloadTo downloads the file behind the key and copies it to w. It
+// returns the number of bytes written, or an error if the key does not
+// exist or the copy fails.
+func DownloadTo(ctx context.Context, key string, w io.Writer, opts ...TransferOption) (int64, error) {
+	return DefaultClient.DownloadTo(ctx, key, w, opts...)
+}
+
+// UploadReader uploads the content read from r, named as name, and returns
+// the API response or an error if it can not. As with Upload, the content is
+// streamed directly to the request body instead of being buffered in memory,
+// making it suitable for large files.
+func (c *Client) UploadReader(ctx context.Context, name string, r io.Reader, opts ...TransferOption) (*Response, error) {
+	return c.uploadStream(ctx, name, r, c.url(""), opts...)
+}
+
+// DownloadTo downloads the file behind the key and copies it to w. It
+// returns the number of bytes written, or an error if the key does not
+// exist or the copy fails.
+func (c *Client) DownloadTo(ctx context.Context, key string, w io.Writer, opts ...TransferOption) (n int64, err error) {
+	cfg := newTransferConfig(opts)
+
+	resp, err := c.getWithRetry(ctx, c.url(key))
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, statusError(key, resp)
+	}
+
+	dst := io.Writer(w)
+	if cfg.progress != nil {
+		dst = &progressWriter{w: w, total: resp.ContentLength, fn: cfg.progress}
+	}
+	return io.Copy(dst, resp.Body)
+}
+
+// uploadStream streams r as the "file" part of a multipart form posted to url.
+func (c *Client) uploadStream(ctx context.Context, name string, r io.Reader, url string, opts ...TransferOption) (*Response, error) {
+	cfg := newTransferConfig(opts)
+
+	size := int64(-1)
+	if f, ok := r.(*os.File); ok {
+		if fi, err := f.Stat(); err == nil {
+			size = fi.Size()
+		}
+	}
+
+	name, contentType, src, err := cfg.validateUpload(name, size, r)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.progress != nil {
+		src = &progressReader{r: src, total: size, fn: cfg.progress}
+	}
+
+	body, bodyType := pipeMultipart(name, contentType, src)
+
+	resp, err := c.do(ctx, http.MethodPost, url, bodyType, body)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	rs, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return parseJSON(rs, resp.StatusCode)
+}
+
+// pipeMultipart builds a single-file multipart form, with the given content
+// type on its part header, and streams its content lazily through an
+// io.Pipe, so the whole body never needs to be buffered.
+func pipeMultipart(name, contentType string, r io.Reader) (io.Reader, string) {
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+
+	go func() {
+		h := make(textproto.MIMEHeader)
+		h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, quoteEscaper.Replace(name)))
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		h.Set("Content-Type", contentType)
+
+		fw, err := mw.CreatePart(h)
+		if err != nil {
+			_ = pw.CloseWithError(err)
+			return
+		}
+		if _, err := io.Copy(fw, r); err != nil {
+			_ = pw.CloseWithError(err)
+			return
+		}
+		if err := mw.Close(); err != nil {
+			_ = pw.CloseWithError(err)
+			return
+		}
+		_ = pw.Close()
+	}()
+
+	return pr, mw.FormDataContentType()
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// progressReader wraps an io.Reader, reporting bytes read through fn.
+type progressReader struct {
+	r     io.Reader
+	done  int64
+	total int64
+	fn    ProgressFunc
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.done += int64(n)
+		p.fn(p.done, p.total)
+	}
+	return n, err
+}
+
+// progressWriter wraps an io.Writer, reporting bytes written through fn.
+type progressWriter struct {
+	w     io.Writer
+	done  int64
+	total int64
+	fn    ProgressFunc
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	if n > 0 {
+		p.done += int64(n)
+		p.fn(p.done, p.total)
+	}
+	return n, err
+}
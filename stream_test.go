@@ -0,0 +1,58 @@
+package fileio
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestUploadReader tests the method UploadReader.
+func TestUploadReader(t *testing.T) {
+	DefaultClient.HTTPClient = &fakeHTTPClient{}
+	defer func() { DefaultClient.HTTPClient = http.DefaultClient }()
+
+	base := DefaultClient.BaseURL
+	defer func() { DefaultClient.BaseURL = base }()
+	DefaultClient.BaseURL = URL
+
+	var done int64
+	rs, err := UploadReader(context.Background(), "test.txt", strings.NewReader("This is a test"), WithProgress(func(d, _ int64) {
+		done = d
+	}))
+	if err != nil {
+		t.Fatalf("expected no error, received %v", err)
+	}
+	if rs.Key != "2ojE41" {
+		t.Errorf("expected key /2ojE41, received /%s", rs.Key)
+	}
+	if done == 0 {
+		t.Errorf("expected progress to be reported")
+	}
+}
+
+// TestDownloadTo tests the method DownloadTo.
+func TestDownloadTo(t *testing.T) {
+	DefaultClient.HTTPClient = &fakeHTTPClient{}
+	defer func() { DefaultClient.HTTPClient = http.DefaultClient }()
+
+	base := DefaultClient.BaseURL
+	defer func() { DefaultClient.BaseURL = base }()
+	DefaultClient.BaseURL = URL
+
+	var buf bytes.Buffer
+	var done int64
+	n, err := DownloadTo(context.Background(), "exists", &buf, WithProgress(func(d, _ int64) {
+		done = d
+	}))
+	if err != nil {
+		t.Fatalf("expected no error, received %v", err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("expected %d bytes written, received %d", buf.Len(), n)
+	}
+	if done == 0 {
+		t.Errorf("expected progress to be reported")
+	}
+}
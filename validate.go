@@ -0,0 +1,144 @@
+package fileio
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+)
+
+// sniffSize is the number of leading bytes inspected to detect a file's
+// content type, mirroring net/http.DetectContentType's own requirement.
+const sniffSize = 512
+
+// SizeError reports that a file exceeds the maximum size allowed for an upload.
+type SizeError struct {
+	// Size is the size of the rejected file, in bytes.
+	Size int64
+	// MaxBytes is the configured limit that was exceeded.
+	MaxBytes int64
+}
+
+func (e *SizeError) Error() string {
+	return fmt.Sprintf("file size %d bytes exceeds the maximum of %d bytes", e.Size, e.MaxBytes)
+}
+
+// MIMETypeError reports that a file's sniffed content type is not allowed
+// for upload, either because it is missing from an allowlist or present in
+// a denylist.
+type MIMETypeError struct {
+	// ContentType is the content type detected for the rejected file.
+	ContentType string
+}
+
+func (e *MIMETypeError) Error() string {
+	return fmt.Sprintf("content type %q is not allowed", e.ContentType)
+}
+
+// WithAllowedMIMETypes restricts uploads to the given content types. The
+// content type is detected by sniffing the first bytes of the file; any
+// other content type is rejected before any network I/O occurs.
+func WithAllowedMIMETypes(types ...string) TransferOption {
+	return func(c *transferConfig) { c.allowedMIME = types }
+}
+
+// WithDeniedMIMETypes rejects uploads whose sniffed content type matches any
+// of the given values, before any network I/O occurs.
+func WithDeniedMIMETypes(types ...string) TransferOption {
+	return func(c *transferConfig) { c.deniedMIME = types }
+}
+
+// WithMaxBytes rejects uploads bigger than max bytes with a *SizeError. When
+// the size of the upload is not known in advance, the limit is still
+// enforced while streaming.
+func WithMaxBytes(max int64) TransferOption {
+	return func(c *transferConfig) { c.maxBytes = max }
+}
+
+// WithRenameRandom replaces the uploaded file name with a random one,
+// keeping its extension.
+func WithRenameRandom(rename bool) TransferOption {
+	return func(c *transferConfig) { c.renameRandom = rename }
+}
+
+// validateUpload sniffs the content type of r and enforces the upload
+// policy carried by c. It returns the (possibly renamed) file name, its
+// sniffed content type, and a reader replaying everything consumed by the
+// checks. size is the known size of r, or -1 if unknown.
+func (c *transferConfig) validateUpload(name string, size int64, r io.Reader) (string, string, io.Reader, error) {
+	if c.maxBytes > 0 && size >= 0 && size > c.maxBytes {
+		return name, "", nil, &SizeError{Size: size, MaxBytes: c.maxBytes}
+	}
+	if c.maxBytes > 0 {
+		r = &maxBytesReader{r: r, max: c.maxBytes}
+	}
+
+	contentType, r, err := sniffContentType(r)
+	if err != nil {
+		return name, "", nil, err
+	}
+	if len(c.allowedMIME) > 0 && !contains(c.allowedMIME, contentType) {
+		return name, "", nil, &MIMETypeError{ContentType: contentType}
+	}
+	if contains(c.deniedMIME, contentType) {
+		return name, "", nil, &MIMETypeError{ContentType: contentType}
+	}
+
+	if c.renameRandom {
+		if name, err = randomName(name); err != nil {
+			return name, "", nil, err
+		}
+	}
+	return name, contentType, r, nil
+}
+
+// sniffContentType detects the content type of r from its first bytes,
+// returning a reader that replays the whole original content.
+func sniffContentType(r io.Reader) (string, io.Reader, error) {
+	head := make([]byte, sniffSize)
+	n, err := io.ReadFull(r, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", nil, err
+	}
+	head = head[:n]
+	return http.DetectContentType(head), io.MultiReader(bytes.NewReader(head), r), nil
+}
+
+// randomName returns a random file name that keeps the extension of name.
+func randomName(name string) (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf) + filepath.Ext(name), nil
+}
+
+func contains(list []string, v string) bool {
+	for _, s := range list {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// maxBytesReader enforces max as a hard cap on the number of bytes read from
+// r, used as a last line of defense when the size of the upload is unknown
+// in advance.
+type maxBytesReader struct {
+	r   io.Reader
+	n   int64
+	max int64
+}
+
+func (m *maxBytesReader) Read(b []byte) (int, error) {
+	n, err := m.r.Read(b)
+	m.n += int64(n)
+	if m.n > m.max {
+		return n, &SizeError{Size: m.n, MaxBytes: m.max}
+	}
+	return n, err
+}
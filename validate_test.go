@@ -0,0 +1,56 @@
+package fileio
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTransferConfigValidateUpload(t *testing.T) {
+	var cfg transferConfig
+
+	// Unrestricted upload: content type is still sniffed and returned.
+	name, contentType, _, err := cfg.validateUpload("test.txt", -1, strings.NewReader("This is a test"))
+	if err != nil {
+		t.Fatalf("expected no error, received %v", err)
+	}
+	if name != "test.txt" {
+		t.Errorf("expected name test.txt, received %s", name)
+	}
+	if contentType != "text/plain; charset=utf-8" {
+		t.Errorf("expected text/plain content type, received %s", contentType)
+	}
+
+	// Oversize upload with a known size is rejected before any read.
+	cfg = transferConfig{maxBytes: 4}
+	if _, _, _, err := cfg.validateUpload("test.txt", 14, strings.NewReader("This is a test")); err == nil {
+		t.Error("expected a *SizeError, received no error")
+	} else if _, ok := err.(*SizeError); !ok {
+		t.Errorf("expected a *SizeError, received %T", err)
+	}
+
+	// Denied content type.
+	cfg = transferConfig{deniedMIME: []string{"text/plain; charset=utf-8"}}
+	if _, _, _, err := cfg.validateUpload("test.txt", -1, strings.NewReader("This is a test")); err == nil {
+		t.Error("expected a *MIMETypeError, received no error")
+	} else if _, ok := err.(*MIMETypeError); !ok {
+		t.Errorf("expected a *MIMETypeError, received %T", err)
+	}
+
+	// Allowlist missing the sniffed content type.
+	cfg = transferConfig{allowedMIME: []string{"image/png"}}
+	if _, _, _, err := cfg.validateUpload("test.txt", -1, strings.NewReader("This is a test")); err == nil {
+		t.Error("expected a *MIMETypeError, received no error")
+	} else if _, ok := err.(*MIMETypeError); !ok {
+		t.Errorf("expected a *MIMETypeError, received %T", err)
+	}
+
+	// Random rename keeps the original extension.
+	cfg = transferConfig{renameRandom: true}
+	name, _, _, err = cfg.validateUpload("test.txt", -1, strings.NewReader("This is a test"))
+	if err != nil {
+		t.Fatalf("expected no error, received %v", err)
+	}
+	if name == "test.txt" || !strings.HasSuffix(name, ".txt") {
+		t.Errorf("expected a renamed file keeping the .txt extension, received %s", name)
+	}
+}